@@ -0,0 +1,156 @@
+package onfido
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/acsellers/go-onfido/store"
+)
+
+// defaultEndpoint is the base URL of the Onfido API.
+const defaultEndpoint = "https://api.onfido.com/v2"
+
+// Client is an Onfido API client.
+type Client interface {
+	UploadDocument(ctx context.Context, dr DocumentRequest) (*Document, error)
+	GetDocument(ctx context.Context, id string) (*Document, error)
+	ListDocuments(applicantID string) *DocumentIterator
+	DownloadDocument(ctx context.Context, id string) (*DocumentDownload, error)
+	DownloadDocumentTo(ctx context.Context, id string, w io.Writer) error
+	DownloadDocumentRange(ctx context.Context, docID string, offset, length int64) (io.ReadCloser, *RangeInfo, error)
+}
+
+// client is the default Client implementation.
+type client struct {
+	token      string
+	endpoint   string
+	httpClient *http.Client
+
+	allowedMediaTypes []string
+	docStore          store.DocumentStore
+	retryPolicy       *RetryPolicy
+	conditionalCache  ConditionalCache
+}
+
+// ClientOption configures a client constructed by NewClient.
+type ClientOption func(*client)
+
+// NewClient creates a new Onfido API client authenticated with apiToken.
+func NewClient(apiToken string, opts ...ClientOption) Client {
+	c := &client{
+		token:             apiToken,
+		endpoint:          defaultEndpoint,
+		httpClient:        http.DefaultClient,
+		allowedMediaTypes: DefaultAllowedMediaTypes,
+		conditionalCache:  newSyncMapConditionalCache(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// NewClientWithRetry creates a new Onfido API client authenticated with
+// apiToken that retries requests according to p. See RetryPolicy.
+func NewClientWithRetry(apiToken string, p RetryPolicy, opts ...ClientOption) Client {
+	c := NewClient(apiToken, opts...).(*client)
+	c.retryPolicy = &p
+	return c
+}
+
+// WithAllowedMediaTypes overrides the set of sniffed content types accepted
+// by UploadDocument. It replaces DefaultAllowedMediaTypes entirely.
+func WithAllowedMediaTypes(mediaTypes ...string) ClientOption {
+	return func(c *client) {
+		c.allowedMediaTypes = mediaTypes
+	}
+}
+
+// WithDocumentCache configures the client to consult s before downloading a
+// document from Onfido, and to populate it on cache misses. See
+// DownloadDocumentTo.
+func WithDocumentCache(s store.DocumentStore) ClientOption {
+	return func(c *client) {
+		c.docStore = s
+	}
+}
+
+// do sends req, decoding a JSON error payload into an *Error for non-2xx
+// responses and the JSON body into out otherwise. When out is nil and the
+// response is successful, the caller is responsible for closing resp.Body.
+//
+// If the client was constructed with NewClientWithRetry, do retries the
+// request according to the configured RetryPolicy. In that case a request
+// with a body must have been built with a replayable body (e.g. a
+// *bytes.Buffer, *bytes.Reader, or *strings.Reader passed to
+// http.NewRequest, which populates req.GetBody automatically); otherwise do
+// returns an error without sending the request.
+func (c *client) do(ctx context.Context, req *http.Request, out interface{}) (*http.Response, error) {
+	req.Header.Set("Authorization", fmt.Sprintf("Token token=%s", c.token))
+
+	policy := c.retryPolicy
+	if policy != nil && req.Body != nil && req.GetBody == nil {
+		return nil, errors.New("onfido: request body must be replayable to use retries")
+	}
+
+	attempts := 1
+	if policy != nil {
+		attempts = policy.MaxRetries + 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.httpClient.Do(req.WithContext(ctx))
+		lastErr = err
+
+		if policy != nil && attempt < attempts-1 && policy.retryableFunc()(resp, err) {
+			wait := policy.backoff(attempt, resp)
+			if resp != nil {
+				resp.Body.Close()
+			}
+			select {
+			case <-time.After(wait):
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			defer resp.Body.Close()
+			var errResp ErrorResponse
+			_ = json.NewDecoder(resp.Body).Decode(&errResp)
+			return resp, &Error{HTTPStatusCode: resp.StatusCode, Resp: &errResp}
+		}
+
+		if out != nil {
+			defer resp.Body.Close()
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				return resp, err
+			}
+		}
+
+		return resp, nil
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,312 @@
+package onfido
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"time"
+
+	"github.com/acsellers/go-onfido/store"
+)
+
+// DocumentType is the type of identity document being uploaded.
+type DocumentType string
+
+// Supported document types.
+const (
+	DocumentTypePassport             DocumentType = "passport"
+	DocumentTypeDrivingLicence       DocumentType = "driving_licence"
+	DocumentTypeNationalIdentityCard DocumentType = "national_identity_card"
+	DocumentTypeIDCard               DocumentType = "national_identity_card"
+)
+
+// DocumentSide is the side of a document being uploaded, where applicable.
+type DocumentSide string
+
+// Supported document sides.
+const (
+	DocumentSideFront DocumentSide = "front"
+	DocumentSideBack  DocumentSide = "back"
+)
+
+// DefaultAllowedMediaTypes is the set of sniffed content types UploadDocument
+// accepts unless a client is constructed with WithAllowedMediaTypes.
+var DefaultAllowedMediaTypes = []string{"image/jpeg", "image/png", "application/pdf"}
+
+// ErrUnsupportedMediaType is returned by UploadDocument when the sniffed
+// content type of File is not in the client's allowlist.
+type ErrUnsupportedMediaType struct {
+	ContentType string
+}
+
+func (e *ErrUnsupportedMediaType) Error() string {
+	return fmt.Sprintf("onfido: unsupported media type %q", e.ContentType)
+}
+
+// Document represents a document associated with an applicant.
+type Document struct {
+	ID           string       `json:"id,omitempty"`
+	Href         string       `json:"href,omitempty"`
+	DownloadHref string       `json:"download_href,omitempty"`
+	FileName     string       `json:"file_name,omitempty"`
+	FileType     string       `json:"file_type,omitempty"`
+	FileSize     int          `json:"file_size,omitempty"`
+	Type         DocumentType `json:"type,omitempty"`
+	Side         DocumentSide `json:"side,omitempty"`
+	ApplicantID  string       `json:"applicant_id,omitempty"`
+}
+
+// Documents represents a list of documents, as returned by the list
+// documents endpoint.
+type Documents struct {
+	Documents []*Document `json:"documents"`
+}
+
+// DocumentRequest represents a request to upload a document for an
+// applicant.
+type DocumentRequest struct {
+	File        io.Reader
+	Type        DocumentType
+	Side        DocumentSide
+	ApplicantID string
+}
+
+// DocumentDownload holds the base64-encoded contents of a downloaded
+// document, as returned by DownloadDocument.
+type DocumentDownload struct {
+	Data string
+}
+
+// sniffReader peeks at the first 512 bytes of an underlying reader for MIME
+// sniffing purposes, then transparently re-serves those bytes (along with
+// the rest of the stream) to callers of Read.
+type sniffReader struct {
+	br *bufio.Reader
+}
+
+func newSniffReader(r io.Reader) *sniffReader {
+	return &sniffReader{br: bufio.NewReaderSize(r, 512)}
+}
+
+// sniff returns the detected content type of the underlying reader without
+// consuming any bytes from it.
+func (s *sniffReader) sniff() (string, error) {
+	peek, err := s.br.Peek(512)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return "", err
+	}
+	return http.DetectContentType(peek), nil
+}
+
+func (s *sniffReader) Read(p []byte) (int, error) {
+	return s.br.Read(p)
+}
+
+func (c *client) isAllowedMediaType(contentType string) bool {
+	for _, allowed := range c.allowedMediaTypes {
+		if allowed == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// UploadDocument uploads a document for an applicant. The first 512 bytes of
+// dr.File are sniffed with http.DetectContentType; if the detected content
+// type is not in the client's allowlist, ErrUnsupportedMediaType is returned
+// and no request is made.
+func (c *client) UploadDocument(ctx context.Context, dr DocumentRequest) (*Document, error) {
+	sr := newSniffReader(dr.File)
+
+	contentType, err := sr.sniff()
+	if err != nil {
+		return nil, err
+	}
+	if !c.isAllowedMediaType(contentType) {
+		return nil, &ErrUnsupportedMediaType{ContentType: contentType}
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	if err := writer.WriteField("applicant_id", dr.ApplicantID); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteField("type", string(dr.Type)); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteField("side", string(dr.Side)); err != nil {
+		return nil, err
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="file"; filename="document"`)
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, sr); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.endpoint+"/documents", body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	var resp Document
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetDocument retrieves a single document by ID.
+func (c *client) GetDocument(ctx context.Context, id string) (*Document, error) {
+	req, err := http.NewRequest(http.MethodGet, c.endpoint+"/documents/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Document
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DocumentIterator allows paging through documents for an applicant.
+type DocumentIterator struct {
+	c           *client
+	applicantID string
+	documents   []*Document
+	fetched     bool
+	index       int
+	err         error
+}
+
+// ListDocuments returns an iterator over the documents for applicantID.
+func (c *client) ListDocuments(applicantID string) *DocumentIterator {
+	return &DocumentIterator{c: c, applicantID: applicantID, index: -1}
+}
+
+// Next advances the iterator, fetching documents from the API on first
+// call. It returns false once the documents are exhausted or an error
+// occurs; the error is available via Err.
+func (i *DocumentIterator) Next(ctx context.Context) bool {
+	if !i.fetched {
+		i.fetched = true
+
+		req, err := http.NewRequest(http.MethodGet, i.c.endpoint+"/documents?applicant_id="+i.applicantID, nil)
+		if err != nil {
+			i.err = err
+			return false
+		}
+
+		var resp Documents
+		if _, err := i.c.do(ctx, req, &resp); err != nil {
+			i.err = err
+			return false
+		}
+		i.documents = resp.Documents
+	}
+
+	i.index++
+	return i.index < len(i.documents)
+}
+
+// Document returns the current document. It must only be called after a
+// call to Next that returned true.
+func (i *DocumentIterator) Document() *Document {
+	return i.documents[i.index]
+}
+
+// Err returns the first error encountered while iterating, if any.
+func (i *DocumentIterator) Err() error {
+	return i.err
+}
+
+// DownloadDocument downloads a document's contents and returns them
+// base64-encoded. For large documents, prefer DownloadDocumentTo.
+func (c *client) DownloadDocument(ctx context.Context, id string) (*DocumentDownload, error) {
+	buf := &bytes.Buffer{}
+	if err := c.DownloadDocumentTo(ctx, id, buf); err != nil {
+		return nil, err
+	}
+	return &DocumentDownload{Data: base64.StdEncoding.EncodeToString(buf.Bytes())}, nil
+}
+
+// DownloadDocumentTo streams a document's contents to w. If the client was
+// configured with WithDocumentCache and a copy of the document is already
+// cached, it is served from there without contacting Onfido; otherwise the
+// document is downloaded and, on success, written through to the cache for
+// subsequent calls.
+func (c *client) DownloadDocumentTo(ctx context.Context, id string, w io.Writer) error {
+	if c.docStore != nil {
+		_, r, err := c.docStore.Get(ctx, id)
+		if err == nil {
+			defer r.Close()
+			_, err := io.Copy(w, r)
+			return err
+		}
+		if err != store.ErrNotFound {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, c.endpoint+"/documents/"+id+"/download", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if c.docStore == nil {
+		_, err := io.Copy(w, resp.Body)
+		return err
+	}
+
+	// Stream the response to w and the store concurrently via a pipe, rather
+	// than buffering the whole document in memory before handing it to
+	// docStore.Put.
+	pr, pw := io.Pipe()
+	meta := store.DocumentMeta{
+		DocumentID:  id,
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        resp.ContentLength,
+		CachedAt:    time.Now(),
+	}
+
+	putErr := make(chan error, 1)
+	go func() {
+		err := c.docStore.Put(ctx, id, meta, pr)
+		// If Put returns (whether it failed or just stopped reading early)
+		// before the writing side is done, unblock any pending or future
+		// pw.Write instead of leaving the io.Copy below stuck forever.
+		pr.CloseWithError(err)
+		putErr <- err
+	}()
+
+	_, copyErr := io.Copy(io.MultiWriter(w, pw), resp.Body)
+	pw.CloseWithError(copyErr)
+
+	if err := <-putErr; err != nil {
+		return err
+	}
+	return copyErr
+}
@@ -4,12 +4,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/acsellers/go-onfido/store"
 )
 
 func TestUploadDocument_NonOKResponse(t *testing.T) {
@@ -25,7 +32,7 @@ func TestUploadDocument_NonOKResponse(t *testing.T) {
 
 	docReq := DocumentRequest{
 		ApplicantID: "",
-		File:        bytes.NewReader([]byte("test")),
+		File:        bytes.NewReader([]byte("%PDF-1.4\n%mock pdf contents")),
 		Type:        DocumentTypeIDCard,
 		Side:        DocumentSideFront,
 	}
@@ -36,6 +43,29 @@ func TestUploadDocument_NonOKResponse(t *testing.T) {
 	}
 }
 
+func TestUploadDocument_UnsupportedMediaType(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("expected request not to reach the server for an unsupported media type")
+	}))
+	defer srv.Close()
+
+	client := NewClient("123").(*client)
+	client.endpoint = srv.URL
+
+	docReq := DocumentRequest{
+		ApplicantID: "",
+		File:        bytes.NewReader([]byte("this is plain text, not an allowed document type")),
+		Type:        DocumentTypeIDCard,
+		Side:        DocumentSideFront,
+	}
+
+	_, err := client.UploadDocument(context.Background(), docReq)
+	var unsupported *ErrUnsupportedMediaType
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("expected ErrUnsupportedMediaType, got %v", err)
+	}
+}
+
 func TestUploadDocument_DocumentUploaded(t *testing.T) {
 	applicantID := "541d040b-89f8-444b-8921-16b1333bf1c6"
 	expected := Document{
@@ -69,7 +99,7 @@ func TestUploadDocument_DocumentUploaded(t *testing.T) {
 
 	d, err := client.UploadDocument(context.Background(), DocumentRequest{
 		ApplicantID: applicantID,
-		File:        bytes.NewReader([]byte("test")),
+		File:        bytes.NewReader([]byte("%PDF-1.4\n%mock pdf contents")),
 		Type:        expected.Type,
 		Side:        expected.Side,
 	})
@@ -247,5 +277,131 @@ func TestDownloadDocument(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	assert.Equal(t, "dGhpcyBpcyBhbiBpbWFn", documentDownload.Data)
+	assert.Equal(t, "dGhpcyBpcyBhbiBpbWFnZQ==", documentDownload.Data)
+}
+
+func TestDownloadDocumentTo_CacheMiss(t *testing.T) {
+	mockDocumentID := "93672a37-8223-48b9-a440-3b5cb52a8e4b"
+	requests := 0
+
+	m := mux.NewRouter()
+	m.HandleFunc("/documents/{documentId}/download", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "image/png")
+		w.WriteHeader(http.StatusOK)
+		_, wErr := w.Write([]byte("this is an image"))
+		assert.NoError(t, wErr)
+	}).Methods("GET")
+	srv := httptest.NewServer(m)
+	defer srv.Close()
+
+	cacheDir, err := ioutil.TempDir("", "go-onfido-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	fsStore, err := store.NewLocalFSStore(cacheDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient("123", WithDocumentCache(fsStore)).(*client)
+	client.endpoint = srv.URL
+
+	var buf bytes.Buffer
+	if err := client.DownloadDocumentTo(context.Background(), mockDocumentID, &buf); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "this is an image", buf.String())
+	assert.Equal(t, 1, requests)
+
+	_, r, err := fsStore.Get(context.Background(), mockDocumentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	cached, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "this is an image", string(cached))
+}
+
+// erroringStore is a store.DocumentStore whose Put fails immediately
+// without reading from the given io.Reader, used to confirm
+// DownloadDocumentTo doesn't deadlock waiting on a store that never drains
+// the pipe.
+type erroringStore struct{}
+
+var errPutFailed = errors.New("put failed")
+
+func (erroringStore) Put(ctx context.Context, docID string, meta store.DocumentMeta, r io.Reader) error {
+	return errPutFailed
+}
+
+func (erroringStore) Get(ctx context.Context, docID string) (store.DocumentMeta, io.ReadCloser, error) {
+	return store.DocumentMeta{}, nil, store.ErrNotFound
+}
+
+func TestDownloadDocumentTo_ReturnsPromptlyWhenStorePutFailsEarly(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("this is an image"))
+	}))
+	defer srv.Close()
+
+	client := NewClient("123", WithDocumentCache(erroringStore{})).(*client)
+	client.endpoint = srv.URL
+
+	done := make(chan error, 1)
+	go func() {
+		var buf bytes.Buffer
+		done <- client.DownloadDocumentTo(context.Background(), "doc-1", &buf)
+	}()
+
+	select {
+	case err := <-done:
+		if err != errPutFailed {
+			t.Fatalf("expected %v, got %v", errPutFailed, err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("DownloadDocumentTo did not return; likely deadlocked on the cache pipe")
+	}
+}
+
+func TestDownloadDocumentTo_CacheHit(t *testing.T) {
+	mockDocumentID := "93672a37-8223-48b9-a440-3b5cb52a8e4b"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("expected request not to reach the server for a cached document")
+	}))
+	defer srv.Close()
+
+	cacheDir, err := ioutil.TempDir("", "go-onfido-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	fsStore, err := store.NewLocalFSStore(cacheDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fsStore.Put(context.Background(), mockDocumentID, store.DocumentMeta{
+		DocumentID:  mockDocumentID,
+		ContentType: "image/png",
+	}, bytes.NewReader([]byte("cached image bytes")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient("123", WithDocumentCache(fsStore)).(*client)
+	client.endpoint = srv.URL
+
+	var buf bytes.Buffer
+	if err := client.DownloadDocumentTo(context.Background(), mockDocumentID, &buf); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "cached image bytes", buf.String())
 }
@@ -0,0 +1,24 @@
+package onfido
+
+import "fmt"
+
+// ErrorResponse is the JSON error payload returned by the Onfido API for
+// non-2xx responses.
+type ErrorResponse struct {
+	Type    string                 `json:"type,omitempty"`
+	Message string                 `json:"message,omitempty"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Error wraps a non-2xx response from the Onfido API.
+type Error struct {
+	HTTPStatusCode int
+	Resp           *ErrorResponse
+}
+
+func (e *Error) Error() string {
+	if e.Resp != nil && e.Resp.Message != "" {
+		return fmt.Sprintf("onfido: %d - %s", e.HTTPStatusCode, e.Resp.Message)
+	}
+	return fmt.Sprintf("onfido: unexpected status code %d", e.HTTPStatusCode)
+}
@@ -0,0 +1,103 @@
+package onfido
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadDocumentRange(t *testing.T) {
+	const etag = `"rev-1"`
+	const lastModified = "Wed, 21 Oct 2015 07:28:00 GMT"
+	mockDocumentID := "93672a37-8223-48b9-a440-3b5cb52a8e4b"
+
+	m := mux.NewRouter()
+	m.HandleFunc("/documents/{documentId}/download", func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		assert.Equal(t, mockDocumentID, vars["documentId"])
+
+		switch r.Header.Get("Range") {
+		case "bytes=0-3":
+			if r.Header.Get("If-None-Match") == etag || r.Header.Get("If-Modified-Since") == lastModified {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Last-Modified", lastModified)
+			w.Header().Set("Content-Range", "bytes 0-3/16")
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte("this"))
+		case "bytes=4-7":
+			// Simulate a server that ignores Range and returns the full body.
+			w.Header().Set("ETag", etag)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("this is an image"))
+		}
+	}).Methods("GET")
+	srv := httptest.NewServer(m)
+	defer srv.Close()
+
+	c := NewClient("123").(*client)
+	c.endpoint = srv.URL
+
+	r, info, err := c.DownloadDocumentRange(context.Background(), mockDocumentID, 0, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "this", string(data))
+	assert.Equal(t, &RangeInfo{Start: 0, End: 3, Total: 16}, info)
+
+	// Fallback path: server responds 200 instead of 206.
+	r, info, err = c.DownloadDocumentRange(context.Background(), mockDocumentID, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	data, err = ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "this is an image", string(data))
+	assert.Nil(t, info)
+
+	// Re-fetching the first range now sends the cached validators and gets
+	// a 304, which should surface as ErrNotModified.
+	_, _, err = c.DownloadDocumentRange(context.Background(), mockDocumentID, 0, 4)
+	assert.Equal(t, ErrNotModified, err)
+}
+
+func TestParseContentRange(t *testing.T) {
+	info, err := parseContentRange("bytes 0-99/200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, &RangeInfo{Start: 0, End: 99, Total: 200}, info)
+
+	info, err = parseContentRange("bytes 0-99/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, &RangeInfo{Start: 0, End: 99, Total: -1}, info)
+
+	_, err = parseContentRange("")
+	if err == nil {
+		t.Fatal("expected error for empty Content-Range header")
+	}
+
+	_, err = parseContentRange("not-a-content-range")
+	if err == nil {
+		t.Fatal("expected error for malformed Content-Range header")
+	}
+}
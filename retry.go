@@ -0,0 +1,98 @@
+package onfido
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryableFunc decides whether a request should be retried given the
+// response from the server (nil on a transport error) and/or the error
+// returned by the HTTP client.
+type RetryableFunc func(resp *http.Response, err error) bool
+
+// RetryPolicy configures the retry behaviour of a client created with
+// NewClientWithRetry.
+type RetryPolicy struct {
+	// MaxRetries is the number of additional attempts made after the first
+	// failed one.
+	MaxRetries int
+	// MinWait is the backoff used for the first retry.
+	MinWait time.Duration
+	// MaxWait caps the backoff between retries, before jitter is applied.
+	MaxWait time.Duration
+	// Multiplier is applied to the wait time after each attempt.
+	Multiplier float64
+	// Retryable decides whether a given response/error should be retried.
+	// DefaultRetryableFunc is used if nil.
+	Retryable RetryableFunc
+}
+
+// DefaultRetryPolicy retries network errors, HTTP 429, and 5xx responses up
+// to 3 times, backing off from 500ms up to 10s.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinWait:    500 * time.Millisecond,
+	MaxWait:    10 * time.Second,
+	Multiplier: 2,
+}
+
+// DefaultRetryableFunc retries network errors, HTTP 429, and 5xx responses.
+func DefaultRetryableFunc(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+func (p *RetryPolicy) retryableFunc() RetryableFunc {
+	if p.Retryable != nil {
+		return p.Retryable
+	}
+	return DefaultRetryableFunc
+}
+
+// backoff returns how long to wait before the next attempt, honoring a
+// Retry-After header on resp if present, and otherwise computing
+// MinWait * Multiplier^attempt capped at MaxWait, with ±20% jitter.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp); ok {
+			return wait
+		}
+	}
+
+	wait := float64(p.MinWait) * math.Pow(p.Multiplier, float64(attempt))
+	if max := float64(p.MaxWait); wait > max {
+		wait = max
+	}
+
+	jitter := wait * 0.2
+	wait += (rand.Float64()*2 - 1) * jitter
+	if wait < 0 {
+		wait = 0
+	}
+
+	return time.Duration(wait)
+}
+
+// retryAfter parses a Retry-After header in either delta-seconds or
+// HTTP-date form.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	ra := resp.Header.Get("Retry-After")
+	if ra == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(ra); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(ra); err == nil {
+		return time.Until(t), true
+	}
+
+	return 0, false
+}
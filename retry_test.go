@@ -0,0 +1,136 @@
+package onfido
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Retry_SucceedsAfterTransientFailures(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"doc-1"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClientWithRetry("123", RetryPolicy{
+		MaxRetries: 3,
+		MinWait:    time.Millisecond,
+		MaxWait:    5 * time.Millisecond,
+		Multiplier: 2,
+	}).(*client)
+	c.endpoint = srv.URL
+
+	d, err := c.GetDocument(context.Background(), "doc-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, "doc-1", d.ID)
+}
+
+func TestClient_Retry_GivesUpAfterMaxRetries(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClientWithRetry("123", RetryPolicy{
+		MaxRetries: 2,
+		MinWait:    time.Millisecond,
+		MaxWait:    5 * time.Millisecond,
+		Multiplier: 2,
+	}).(*client)
+	c.endpoint = srv.URL
+
+	_, err := c.GetDocument(context.Background(), "doc-1")
+	if err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+
+	assert.Equal(t, 3, requests)
+}
+
+func TestClient_Retry_NonRetryableStatusPassesThroughImmediately(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := NewClientWithRetry("123", RetryPolicy{
+		MaxRetries: 3,
+		MinWait:    time.Millisecond,
+		MaxWait:    5 * time.Millisecond,
+		Multiplier: 2,
+	}).(*client)
+	c.endpoint = srv.URL
+
+	_, err := c.GetDocument(context.Background(), "doc-1")
+	if err == nil {
+		t.Fatal("expected error for 404 response")
+	}
+
+	assert.Equal(t, 1, requests)
+}
+
+func TestClient_Retry_HonorsRetryAfterDeltaSeconds(t *testing.T) {
+	var requests int
+	var firstAttempt time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			firstAttempt = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"doc-1"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClientWithRetry("123", RetryPolicy{
+		MaxRetries: 1,
+		MinWait:    time.Millisecond,
+		MaxWait:    5 * time.Millisecond,
+		Multiplier: 2,
+	}).(*client)
+	c.endpoint = srv.URL
+
+	_, err := c.GetDocument(context.Background(), "doc-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, requests)
+	assert.GreaterOrEqual(t, time.Since(firstAttempt), time.Second)
+}
+
+func TestRetryPolicy_Backoff_RespectsMaxWaitAndJitter(t *testing.T) {
+	p := &RetryPolicy{
+		MinWait:    100 * time.Millisecond,
+		MaxWait:    200 * time.Millisecond,
+		Multiplier: 10,
+	}
+
+	wait := p.backoff(5, nil)
+	assert.LessOrEqual(t, wait, 240*time.Millisecond)
+	assert.True(t, wait >= 0)
+}
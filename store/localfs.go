@@ -0,0 +1,89 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// LocalFSStore is a DocumentStore backed by a directory on the local
+// filesystem. Each document is written to <dir>/<docID>, with its metadata
+// alongside in <dir>/<docID>.json.
+type LocalFSStore struct {
+	dir string
+}
+
+// NewLocalFSStore creates a LocalFSStore rooted at dir, creating it if it
+// does not already exist.
+func NewLocalFSStore(dir string) (*LocalFSStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalFSStore{dir: dir}, nil
+}
+
+func (s *LocalFSStore) dataPath(docID string) string {
+	return filepath.Join(s.dir, docID)
+}
+
+func (s *LocalFSStore) metaPath(docID string) string {
+	return s.dataPath(docID) + ".json"
+}
+
+// Put implements DocumentStore.
+func (s *LocalFSStore) Put(ctx context.Context, docID string, meta DocumentMeta, r io.Reader) error {
+	if err := ValidateDocID(docID); err != nil {
+		return err
+	}
+
+	f, err := os.Create(s.dataPath(docID))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		// Don't leave a partial, metadata-less file behind for a later Get
+		// to stumble over.
+		os.Remove(s.dataPath(docID))
+		return err
+	}
+
+	metaBytes, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.metaPath(docID), metaBytes, 0o644)
+}
+
+// Get implements DocumentStore.
+func (s *LocalFSStore) Get(ctx context.Context, docID string) (DocumentMeta, io.ReadCloser, error) {
+	var meta DocumentMeta
+
+	if err := ValidateDocID(docID); err != nil {
+		return meta, nil, err
+	}
+
+	metaBytes, err := os.ReadFile(s.metaPath(docID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return meta, nil, ErrNotFound
+		}
+		return meta, nil, err
+	}
+	if err := json.Unmarshal(metaBytes, &meta); err != nil {
+		return meta, nil, err
+	}
+
+	f, err := os.Open(s.dataPath(docID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return meta, nil, ErrNotFound
+		}
+		return meta, nil, err
+	}
+
+	return meta, f, nil
+}
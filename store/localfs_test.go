@@ -0,0 +1,88 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalFSStore_PutGet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-onfido-localfs-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := NewLocalFSStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta := DocumentMeta{DocumentID: "doc-1", ContentType: "image/png", Size: 5}
+	if err := s.Put(context.Background(), "doc-1", meta, bytes.NewReader([]byte("hello"))); err != nil {
+		t.Fatal(err)
+	}
+
+	gotMeta, r, err := s.Get(context.Background(), "doc-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "hello", string(data))
+	assert.Equal(t, meta.ContentType, gotMeta.ContentType)
+	assert.Equal(t, meta.Size, gotMeta.Size)
+}
+
+func TestLocalFSStore_GetNotFound(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-onfido-localfs-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := NewLocalFSStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = s.Get(context.Background(), "missing")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestLocalFSStore_RejectsPathTraversal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-onfido-localfs-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cacheDir := filepath.Join(dir, "cache")
+
+	s, err := NewLocalFSStore(cacheDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Put(context.Background(), "../outside", DocumentMeta{}, bytes.NewReader([]byte("evil")))
+	if err == nil {
+		t.Fatal("expected Put to reject a document id containing path traversal")
+	}
+	if _, statErr := os.Stat(filepath.Join(dir, "outside")); !os.IsNotExist(statErr) {
+		t.Fatal("expected Put not to write outside the store's directory")
+	}
+
+	_, _, err = s.Get(context.Background(), "../outside")
+	if err == nil {
+		t.Fatal("expected Get to reject a document id containing path traversal")
+	}
+}
@@ -0,0 +1,113 @@
+// Package s3store is an S3-backed onfido/store.DocumentStore. It is kept
+// separate from the store package so that consumers who don't use
+// WithDocumentCache against S3 don't transitively pull in aws-sdk-go.
+package s3store
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+
+	"github.com/acsellers/go-onfido/store"
+)
+
+// metadataKey is the S3 object metadata key under which the serialized
+// store.DocumentMeta for a cached document is stored.
+const metadataKey = "Onfido-Meta"
+
+// Store is a store.DocumentStore backed by an S3 bucket. Credentials and
+// region are resolved from the standard AWS_* environment variables via the
+// default AWS session chain.
+type Store struct {
+	bucket   string
+	prefix   string
+	svc      *s3.S3
+	uploader *s3manager.Uploader
+}
+
+// New creates a Store that stores documents under prefix in bucket, using
+// credentials resolved from the environment.
+func New(bucket, prefix string) (*Store, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Store{
+		bucket:   bucket,
+		prefix:   prefix,
+		svc:      s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+	}, nil
+}
+
+func (s *Store) key(docID string) (string, error) {
+	if err := store.ValidateDocID(docID); err != nil {
+		return "", err
+	}
+	return path.Join(s.prefix, docID), nil
+}
+
+// Put implements store.DocumentStore. It streams r to S3 via a multipart
+// upload rather than buffering the document in memory.
+func (s *Store) Put(ctx context.Context, docID string, meta store.DocumentMeta, r io.Reader) error {
+	key, err := s.key(docID)
+	if err != nil {
+		return err
+	}
+
+	metaJSON, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	input := &s3manager.UploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   r,
+		Metadata: map[string]*string{
+			metadataKey: aws.String(string(metaJSON)),
+		},
+	}
+	if meta.ContentType != "" {
+		input.ContentType = aws.String(meta.ContentType)
+	}
+
+	_, err = s.uploader.UploadWithContext(ctx, input)
+	return err
+}
+
+// Get implements store.DocumentStore.
+func (s *Store) Get(ctx context.Context, docID string) (store.DocumentMeta, io.ReadCloser, error) {
+	var meta store.DocumentMeta
+
+	key, err := s.key(docID)
+	if err != nil {
+		return meta, nil, err
+	}
+
+	out, err := s.svc.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && (aerr.Code() == s3.ErrCodeNoSuchKey || aerr.Code() == "NotFound") {
+			return meta, nil, store.ErrNotFound
+		}
+		return meta, nil, err
+	}
+
+	if raw := out.Metadata[metadataKey]; raw != nil {
+		_ = json.Unmarshal([]byte(*raw), &meta)
+	}
+
+	return meta, out.Body, nil
+}
@@ -0,0 +1,22 @@
+package s3store
+
+import "testing"
+
+func TestStore_KeyRejectsPathTraversal(t *testing.T) {
+	s, err := New("test-bucket", "documents")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.key("../outside"); err == nil {
+		t.Fatal("expected key to reject a document id containing path traversal")
+	}
+
+	key, err := s.key("doc-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "documents/doc-1" {
+		t.Fatalf("expected key %q, got %q", "documents/doc-1", key)
+	}
+}
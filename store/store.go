@@ -0,0 +1,47 @@
+// Package store provides pluggable cache/mirror backends for documents
+// downloaded from the Onfido API.
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned by DocumentStore.Get when no document has been
+// cached under the given ID.
+var ErrNotFound = errors.New("store: document not found")
+
+// ValidateDocID rejects document IDs that are empty, refer to the current
+// or parent directory, or contain a path separator. Implementations key
+// their backing storage (filesystem paths, S3 object keys, ...) directly
+// off docID, so callers must use this to guard against a caller-supplied ID
+// like "../../etc/passwd" escaping the configured storage root.
+func ValidateDocID(docID string) error {
+	if docID == "" || docID == "." || docID == ".." || strings.ContainsAny(docID, `/\`) {
+		return fmt.Errorf("store: invalid document id %q", docID)
+	}
+	return nil
+}
+
+// DocumentMeta describes a cached document.
+type DocumentMeta struct {
+	DocumentID  string    `json:"document_id"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CachedAt    time.Time `json:"cached_at"`
+}
+
+// DocumentStore caches downloaded documents so repeat fetches can be served
+// without a round-trip to Onfido.
+type DocumentStore interface {
+	// Put stores the contents read from r under docID, along with meta.
+	Put(ctx context.Context, docID string, meta DocumentMeta, r io.Reader) error
+
+	// Get returns the metadata and contents previously stored under docID.
+	// It returns ErrNotFound if docID has not been cached.
+	Get(ctx context.Context, docID string) (DocumentMeta, io.ReadCloser, error)
+}